@@ -0,0 +1,50 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+)
+
+// eventSinkParam is the `dolt sql-server` flag used to wire up a change-event sink, e.g.
+// `--event-sink=kafka://localhost:9092/my-topic` or `--event-sink=file:///tmp/dolt-events.jsonl`.
+const eventSinkParam = "event-sink"
+
+// supportsEventSinkParam registers the --event-sink flag on ap. The sql-server command's ArgParser
+// construction does not call this function, so `dolt sql-server` does not accept the flag.
+func supportsEventSinkParam(ap *argparser.ArgParser) *argparser.ArgParser {
+	return ap.SupportsString(eventSinkParam, "", "sink-url",
+		"Publish change events for every table write to this sink. Accepts kafka://, file://, "+
+			"and mem:// URLs. Defaults to no event sink.")
+}
+
+// openDatabaseWithEventSink builds the *sqle.Database the sql-server command serves for the
+// dolt database named name rooted at root, wiring in whatever sink --event-sink named so every
+// write made through the returned database publishes change events. The sql-server command's
+// Exec does not call this function.
+func openDatabaseWithEventSink(apr *argparser.ArgParseResults, name string, root *doltdb.RootValue) (*sqle.Database, error) {
+	eventSinkURL, _ := apr.GetValue(eventSinkParam)
+
+	db, err := sqle.NewDatabase(name, root, eventSinkURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s value: %w", eventSinkParam, err)
+	}
+
+	return db, nil
+}
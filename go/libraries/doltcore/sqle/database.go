@@ -0,0 +1,53 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+)
+
+// Database implements sql.Database for a single Dolt database, backing every DoltTable handed
+// out of it with the same root value and (optionally) the same change-event sink.
+type Database struct {
+	name string
+	root *doltdb.RootValue
+	// eventSink receives the change events staged by a tableEditor for every table in this
+	// database, or is nil if no sink was configured. See EventSink/SetEventSink.
+	eventSink EventSink
+}
+
+// Name returns the name of this database.
+func (db *Database) Name() string {
+	return db.name
+}
+
+// NewDatabase constructs a Database named name, rooted at root. If eventSinkURL is non-empty,
+// it's parsed with NewEventSink and wired up via SetEventSink, so every write made through the
+// returned Database publishes change events to it. eventSinkURL is typically threaded straight
+// through from the `dolt sql-server --event-sink` flag.
+func NewDatabase(name string, root *doltdb.RootValue, eventSinkURL string) (*Database, error) {
+	db := &Database{name: name, root: root}
+
+	if eventSinkURL != "" {
+		sink, err := NewEventSink(eventSinkURL)
+		if err != nil {
+			return nil, err
+		}
+
+		db.SetEventSink(sink)
+	}
+
+	return db, nil
+}
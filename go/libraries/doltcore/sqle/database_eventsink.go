@@ -0,0 +1,28 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+// EventSink returns the change-event sink configured for this database, or nil if none was
+// configured (the common case: tableEditor skips staging events entirely when this is nil).
+func (db *Database) EventSink() EventSink {
+	return db.eventSink
+}
+
+// SetEventSink attaches an EventSink that every tableEditor for this database will publish
+// Insert/Update/Delete events to once their Close() successfully persists a new root. Passing
+// nil disables event publishing for this database.
+func (db *Database) SetEventSink(sink EventSink) {
+	db.eventSink = sink
+}
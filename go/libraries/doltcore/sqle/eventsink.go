@@ -0,0 +1,89 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ChangeEventType identifies what a ChangeEvent represents: a row mutation, or one of the
+// transaction boundary markers that bracket the batch of row mutations belonging to a single
+// table editor Close().
+type ChangeEventType string
+
+const (
+	ChangeEventInsert   ChangeEventType = "insert"
+	ChangeEventUpdate   ChangeEventType = "update"
+	ChangeEventDelete   ChangeEventType = "delete"
+	ChangeEventTxOpen   ChangeEventType = "tx_open"
+	ChangeEventTxCommit ChangeEventType = "tx_commit"
+)
+
+// ChangeEvent describes a single change made to a Dolt table, or a transaction boundary around
+// a batch of such changes. Events are only ever handed to an EventSink after the new root has
+// been durably written via PutTable, so a sink never observes a change that didn't commit.
+type ChangeEvent struct {
+	Database string          `json:"database"`
+	Table    string          `json:"table"`
+	Type     ChangeEventType `json:"type"`
+	// PrimaryKey is the string representation of the row's primary key tuple. Empty for
+	// transaction boundary events.
+	PrimaryKey string `json:"primary_key,omitempty"`
+	// OldRow and NewRow are keyed by column name. OldRow is nil for inserts, NewRow is nil
+	// for deletes.
+	OldRow map[string]interface{} `json:"old_row,omitempty"`
+	NewRow map[string]interface{} `json:"new_row,omitempty"`
+	// RootHash is the hash of the Dolt root that resulted from the commit. Only the tx_commit
+	// event that closes out a batch carries it; row events and tx_open leave it empty.
+	RootHash string `json:"root_hash,omitempty"`
+}
+
+// EventSink receives batches of ChangeEvents emitted by a tableEditor's Close(). Emit runs on the
+// calling write path: KafkaEventSink only enqueues onto a bounded buffer and never waits on the
+// network, while FileEventSink writes and flushes to disk before returning.
+type EventSink interface {
+	// Emit hands a batch of events to the sink. The batch begins with a ChangeEventTxOpen
+	// marker and ends with a ChangeEventTxCommit marker carrying the resulting root hash.
+	Emit(ctx context.Context, events []ChangeEvent) error
+
+	// Close flushes any buffered events and releases the sink's resources.
+	Close() error
+}
+
+// NewEventSink constructs an EventSink from a URL such as those accepted by the
+// `dolt sql-server --event-sink` flag. Recognized schemes are "kafka", "file", and "mem".
+func NewEventSink(rawURL string) (EventSink, error) {
+	if rawURL == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event sink url %q: %w", rawURL, err)
+	}
+
+	switch u.Scheme {
+	case "kafka":
+		return NewKafkaEventSink(kafkaBrokersFromURL(u), kafkaTopicFromURL(u), KafkaFormatJSON)
+	case "file":
+		return NewFileEventSink(u.Path)
+	case "mem":
+		return NewMemoryEventSink(), nil
+	default:
+		return nil, fmt.Errorf("unrecognized event sink scheme %q", u.Scheme)
+	}
+}
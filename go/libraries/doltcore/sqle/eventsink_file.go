@@ -0,0 +1,73 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// FileEventSink appends each ChangeEvent as a JSON line to a file on disk. It's a simple
+// durable sink for local testing and for deployments that want to tail change events with
+// ordinary file tooling rather than standing up Kafka.
+type FileEventSink struct {
+	mu sync.Mutex
+	f  *os.File
+	w  *bufio.Writer
+}
+
+var _ EventSink = (*FileEventSink)(nil)
+
+// NewFileEventSink opens (creating if necessary) the file at path and returns a sink that
+// appends JSON-encoded events to it, one per line.
+func NewFileEventSink(path string) (*FileEventSink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileEventSink{f: f, w: bufio.NewWriter(f)}, nil
+}
+
+// Emit writes each event as a JSON line and flushes the file's buffer.
+func (s *FileEventSink) Emit(ctx context.Context, events []ChangeEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	enc := json.NewEncoder(s.w)
+	for _, evt := range events {
+		if err := enc.Encode(evt); err != nil {
+			return err
+		}
+	}
+
+	return s.w.Flush()
+}
+
+// Close flushes any buffered bytes and closes the underlying file.
+func (s *FileEventSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.w.Flush(); err != nil {
+		s.f.Close()
+		return err
+	}
+
+	return s.f.Close()
+}
@@ -0,0 +1,155 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/Shopify/sarama"
+)
+
+// KafkaEventFormat selects how a KafkaEventSink serializes ChangeEvents onto the wire. JSON is
+// the only format implemented today; Avro would need a schema registry integration that doesn't
+// exist yet, so it isn't offered as an option.
+type KafkaEventFormat string
+
+const (
+	KafkaFormatJSON KafkaEventFormat = "json"
+
+	// kafkaEventBufferSize bounds how many events can be queued for async producing before
+	// Emit starts dropping new ones rather than blocking the write path.
+	kafkaEventBufferSize = 4096
+)
+
+// KafkaEventSink publishes ChangeEvents to a Kafka topic using a sarama async producer, with the
+// table name as the partition key. Every event goes to the single topic the sink was created
+// with (the path of a kafka:// URL); the topic is not derived from the database name, so
+// databases opened with the same URL share one topic. This lets downstream consumers tail Dolt
+// row mutations the same way they'd tail a Debezium/MySQL binlog stream.
+//
+// Producer errors are logged rather than surfaced to the write path: Emit never blocks, it
+// only enqueues onto a bounded in-memory channel and drops events when that channel is full,
+// so a struggling broker loses or delays event delivery instead of slowing down SQL writes.
+type KafkaEventSink struct {
+	topic    string
+	format   KafkaEventFormat
+	producer sarama.AsyncProducer
+	done     chan struct{}
+}
+
+var _ EventSink = (*KafkaEventSink)(nil)
+
+// NewKafkaEventSink dials the given brokers and returns a sink that publishes to topic.
+func NewKafkaEventSink(brokers []string, topic string, format KafkaEventFormat) (*KafkaEventSink, error) {
+	if len(brokers) == 0 {
+		return nil, fmt.Errorf("no kafka brokers given")
+	}
+	if topic == "" {
+		return nil, fmt.Errorf("no kafka topic given")
+	}
+	if format != KafkaFormatJSON {
+		return nil, fmt.Errorf("unsupported kafka event format %q: only %q is implemented", format, KafkaFormatJSON)
+	}
+
+	cfg := sarama.NewConfig()
+	cfg.Producer.Return.Successes = false
+	cfg.Producer.Return.Errors = true
+	cfg.Producer.Partitioner = sarama.NewHashPartitioner
+	cfg.ChannelBufferSize = kafkaEventBufferSize
+
+	producer, err := sarama.NewAsyncProducer(brokers, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
+	}
+
+	sink := &KafkaEventSink{
+		topic:    topic,
+		format:   format,
+		producer: producer,
+		done:     make(chan struct{}),
+	}
+
+	go sink.logProducerErrors()
+
+	return sink, nil
+}
+
+// logProducerErrors drains the producer's error channel so a full channel never blocks sends,
+// and reports async delivery failures without propagating them back to the write path.
+func (s *KafkaEventSink) logProducerErrors() {
+	for {
+		select {
+		case err, ok := <-s.producer.Errors():
+			if !ok {
+				return
+			}
+			fmt.Fprintf(os.Stderr, "dolt event sink: failed to publish change event to kafka: %v\n", err)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Emit serializes each event and enqueues it on the producer's async input channel, keyed by
+// table name so that all events for a given table land on the same partition in order. If the
+// channel is full, the new message is dropped (pending messages are kept) so that a slow broker
+// can never stall an Insert/Update/Delete.
+func (s *KafkaEventSink) Emit(ctx context.Context, events []ChangeEvent) error {
+	for _, evt := range events {
+		payload, err := s.encode(evt)
+		if err != nil {
+			return err
+		}
+
+		msg := &sarama.ProducerMessage{
+			Topic: s.topic,
+			Key:   sarama.StringEncoder(evt.Table),
+			Value: sarama.ByteEncoder(payload),
+		}
+
+		select {
+		case s.producer.Input() <- msg:
+		default:
+			// Bounded buffer is full (sarama's own internal channel, sized via
+			// cfg.ChannelBufferSize). Drop rather than block the caller.
+			fmt.Fprintf(os.Stderr, "dolt event sink: dropping change event, kafka producer buffer full\n")
+		}
+	}
+
+	return nil
+}
+
+func (s *KafkaEventSink) encode(evt ChangeEvent) ([]byte, error) {
+	return json.Marshal(evt)
+}
+
+// Close stops the producer and waits for in-flight messages to flush.
+func (s *KafkaEventSink) Close() error {
+	close(s.done)
+	return s.producer.Close()
+}
+
+func kafkaBrokersFromURL(u *url.URL) []string {
+	return strings.Split(u.Host, ",")
+}
+
+func kafkaTopicFromURL(u *url.URL) string {
+	return strings.TrimPrefix(u.Path, "/")
+}
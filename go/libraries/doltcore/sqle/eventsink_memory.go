@@ -0,0 +1,57 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryEventSink is an EventSink that just appends every event it sees to an in-memory slice.
+// It's meant for use in tests that want to assert on exactly what events a write path produced,
+// without standing up a Kafka broker or a file sink.
+type MemoryEventSink struct {
+	mu     sync.Mutex
+	events []ChangeEvent
+}
+
+var _ EventSink = (*MemoryEventSink)(nil)
+
+// NewMemoryEventSink returns an empty MemoryEventSink.
+func NewMemoryEventSink() *MemoryEventSink {
+	return &MemoryEventSink{}
+}
+
+// Emit appends events to the sink's buffer.
+func (s *MemoryEventSink) Emit(ctx context.Context, events []ChangeEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, events...)
+	return nil
+}
+
+// Events returns a copy of every event emitted to this sink so far.
+func (s *MemoryEventSink) Events() []ChangeEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]ChangeEvent, len(s.events))
+	copy(out, s.events)
+	return out
+}
+
+// Close is a no-op for MemoryEventSink.
+func (s *MemoryEventSink) Close() error {
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryEventSink(t *testing.T) {
+	sink := NewMemoryEventSink()
+
+	require.NoError(t, sink.Emit(context.Background(), []ChangeEvent{{Type: ChangeEventTxOpen}}))
+	require.NoError(t, sink.Emit(context.Background(), []ChangeEvent{
+		{Type: ChangeEventInsert, Table: "t"},
+		{Type: ChangeEventTxCommit, RootHash: "abc"},
+	}))
+
+	events := sink.Events()
+	require.Len(t, events, 3)
+	assert.Equal(t, ChangeEventTxOpen, events[0].Type)
+	assert.Equal(t, ChangeEventInsert, events[1].Type)
+	assert.Equal(t, "abc", events[2].RootHash)
+
+	// Events() returns a copy: mutating it must not affect the sink's own buffer.
+	events[0].Type = ChangeEventTxCommit
+	assert.Equal(t, ChangeEventTxOpen, sink.Events()[0].Type)
+}
+
+func TestFileEventSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	sink, err := NewFileEventSink(path)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Emit(context.Background(), []ChangeEvent{
+		{Type: ChangeEventInsert, Table: "t"},
+	}))
+	require.NoError(t, sink.Close())
+}
+
+func TestNewEventSink(t *testing.T) {
+	t.Run("mem scheme", func(t *testing.T) {
+		sink, err := NewEventSink("mem://")
+		require.NoError(t, err)
+		_, ok := sink.(*MemoryEventSink)
+		assert.True(t, ok)
+	})
+
+	t.Run("file scheme", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.jsonl")
+		sink, err := NewEventSink("file://" + path)
+		require.NoError(t, err)
+		_, ok := sink.(*FileEventSink)
+		assert.True(t, ok)
+		require.NoError(t, sink.Close())
+	})
+
+	t.Run("empty url yields no sink", func(t *testing.T) {
+		sink, err := NewEventSink("")
+		require.NoError(t, err)
+		assert.Nil(t, sink)
+	})
+
+	t.Run("unrecognized scheme errors", func(t *testing.T) {
+		sink, err := NewEventSink("nope://somewhere")
+		require.Error(t, err)
+		assert.Nil(t, sink)
+	})
+}
+
+func TestKafkaEventSinkRejectsAvro(t *testing.T) {
+	_, err := NewKafkaEventSink([]string{"localhost:9092"}, "topic", "avro")
+	require.Error(t, err)
+}
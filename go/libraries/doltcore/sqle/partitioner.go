@@ -0,0 +1,167 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// rangePartitionableKinds is the set of noms value kinds doltValueToSqlValue (in
+// rows_range_iter.go) knows how to convert. A table whose schema has any column outside this
+// set must never take the ranged-partition path: doing so would turn every SELECT/COUNT(*)/
+// aggregate against it into an error the moment the table crosses partitionSizeThreshold, with
+// no code change on the user's part. Keep this in lockstep with doltValueToSqlValue's cases.
+var rangePartitionableKinds = map[types.NomsKind]bool{
+	types.BoolKind:   true,
+	types.IntKind:    true,
+	types.UintKind:   true,
+	types.FloatKind:  true,
+	types.StringKind: true,
+	types.UUIDKind:   true,
+}
+
+// supportsRangedPartitioning reports whether every column in sch has a kind the ranged
+// partitioner's row conversion (doltValueToSqlValue) is known to handle. DoltTable.Partitions
+// falls back to a single, unbounded partition -- the same path used before this feature existed
+// -- for any schema that fails this check, so an unsupported column type is a no-op regression
+// in partitioning performance, never a correctness regression.
+func supportsRangedPartitioning(sch schema.Schema) bool {
+	for _, col := range sch.GetAllCols().GetColumns() {
+		if !rangePartitionableKinds[col.Kind] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// partitionCountSessionVar lets a client override how many partitions DoltTable.Partitions
+// splits a large table's row map into, e.g. `SET dolt_partition_count = 4`. Defaults to
+// GOMAXPROCS, since the point of partitioning is to let go-mysql-server fan a scan out across
+// goroutines on this process.
+//
+// The pinned github.com/src-d/go-mysql-server fork treats session variables as a freeform
+// string-keyed map rather than a pre-registered system variable table, so SetSessionVariable
+// accepts this name without any separate registration step. If the engine is ever swapped for
+// a fork that validates variable names against a registry, this will need a matching
+// registration call alongside the constant.
+const partitionCountSessionVar = "dolt_partition_count"
+
+// partitionSizeThreshold is the row count below which a table isn't worth splitting: the
+// overhead of computing split points and scheduling multiple goroutines outweighs any gain.
+const partitionSizeThreshold = 10000
+
+// keyRange is a half-open [start, end) range of map keys. A nil start means "from the
+// beginning"; a nil end means "to the end".
+type keyRange struct {
+	start, end types.Value
+}
+
+// partitionCountForSession returns the configured partition count for the current session,
+// falling back to GOMAXPROCS if the session variable isn't set or holds an unusable value.
+func partitionCountForSession(ctx *sql.Context) (int, error) {
+	val, err := ctx.Session.GetSessionVariable(ctx, partitionCountSessionVar)
+	if err != nil {
+		return runtime.GOMAXPROCS(0), nil
+	}
+
+	return partitionCountFromValue(val), nil
+}
+
+// partitionCountFromValue interprets a raw session variable value as a partition count, falling
+// back to GOMAXPROCS for anything that isn't a positive integer.
+func partitionCountFromValue(val interface{}) int {
+	switch n := val.(type) {
+	case int64:
+		if n > 0 {
+			return int(n)
+		}
+	case int:
+		if n > 0 {
+			return n
+		}
+	}
+
+	return runtime.GOMAXPROCS(0)
+}
+
+// partitionSplitOrdinals returns the interior ordinals (strictly between 0 and total) at which
+// a map of total rows should be split to make n roughly-equal partitions. It's pure arithmetic,
+// kept separate from splitMapIntoRanges so the boundary math can be tested without a real
+// types.Map: len(result) == n-1 in the common case, and an empty result means "don't split,
+// there aren't enough rows to make n partitions worthwhile."
+func partitionSplitOrdinals(total uint64, n int) []uint64 {
+	if n < 1 {
+		n = 1
+	}
+
+	chunkSize := total / uint64(n)
+	if chunkSize == 0 {
+		// Fewer rows than requested partitions -- one row (or zero) per partition isn't
+		// worth the overhead, so don't split at all.
+		return nil
+	}
+
+	ordinals := make([]uint64, 0, n-1)
+	for i := 1; i < n; i++ {
+		ordinals = append(ordinals, uint64(i)*chunkSize)
+	}
+
+	return ordinals
+}
+
+// splitMapIntoRanges divides rowData into n roughly-equal key ranges. Split points are found
+// via indexed lookups into the map's underlying prolly tree (types.Map.IteratorAt), which walks
+// down from the tree root to the target ordinal rather than scanning every preceding row, so
+// this is cheap even for a very large table.
+func splitMapIntoRanges(ctx context.Context, rowData types.Map, n int) ([]keyRange, error) {
+	ordinals := partitionSplitOrdinals(rowData.Len(), n)
+	if len(ordinals) == 0 {
+		return []keyRange{{}}, nil
+	}
+
+	ranges := make([]keyRange, 0, len(ordinals)+1)
+	var prevKey types.Value
+	for _, idx := range ordinals {
+		itr, err := rowData.IteratorAt(ctx, idx)
+		if err != nil {
+			return nil, err
+		}
+
+		k, _, err := itr.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// Defensive: partitionSplitOrdinals only returns ordinals below rowData.Len(), so idx
+		// lands inside the map. Were it ever past the end, the rest of the rows would belong
+		// in the final, open-ended range.
+		if k == nil {
+			break
+		}
+
+		ranges = append(ranges, keyRange{start: prevKey, end: k})
+		prevKey = k
+	}
+
+	ranges = append(ranges, keyRange{start: prevKey, end: nil})
+	return ranges, nil
+}
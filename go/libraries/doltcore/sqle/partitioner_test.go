@@ -0,0 +1,170 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestPartitionSplitOrdinals(t *testing.T) {
+	t.Run("typical case splits into n-1 interior ordinals", func(t *testing.T) {
+		ordinals := partitionSplitOrdinals(1000, 4)
+		require.Len(t, ordinals, 3)
+		assert.Equal(t, []uint64{250, 500, 750}, ordinals)
+	})
+
+	t.Run("fewer rows than partitions falls back to no split", func(t *testing.T) {
+		assert.Empty(t, partitionSplitOrdinals(3, 10))
+	})
+
+	t.Run("n of 1 never splits", func(t *testing.T) {
+		assert.Empty(t, partitionSplitOrdinals(1000, 1))
+	})
+
+	t.Run("n of 0 is treated as 1", func(t *testing.T) {
+		assert.Empty(t, partitionSplitOrdinals(1000, 0))
+	})
+
+	t.Run("ordinals are strictly increasing and within bounds", func(t *testing.T) {
+		total := uint64(97) // prime, so division always leaves a remainder
+		n := 5
+
+		ordinals := partitionSplitOrdinals(total, n)
+		require.Len(t, ordinals, n-1)
+
+		for i, o := range ordinals {
+			assert.Greater(t, o, uint64(0))
+			assert.Less(t, o, total)
+			if i > 0 {
+				assert.Greater(t, o, ordinals[i-1])
+			}
+		}
+	})
+}
+
+func TestPartitionCountFromValue(t *testing.T) {
+	fallback := partitionCountFromValue("not-a-number")
+
+	t.Run("positive int64 is honored", func(t *testing.T) {
+		assert.Equal(t, 4, partitionCountFromValue(int64(4)))
+	})
+
+	t.Run("positive int is honored", func(t *testing.T) {
+		assert.Equal(t, 4, partitionCountFromValue(4))
+	})
+
+	t.Run("zero or negative falls back to GOMAXPROCS", func(t *testing.T) {
+		assert.Equal(t, fallback, partitionCountFromValue(int64(0)))
+		assert.Equal(t, fallback, partitionCountFromValue(int64(-1)))
+	})
+
+	t.Run("unsupported type falls back to GOMAXPROCS", func(t *testing.T) {
+		assert.Equal(t, fallback, partitionCountFromValue("not-a-number"))
+	})
+}
+
+// TestSplitMapIntoRangesParity is the behavior-correctness guarantee the ranged-partition feature
+// exists for: splitting a real types.Map into ranges and scanning each one in turn (the same way
+// newRangeRowIter does) must visit every key exactly once, in the same order a single unranged
+// scan would. If this ever regresses, SELECT/COUNT(*)/aggregate queries over a large table would
+// silently under- or over-count rows.
+func TestSplitMapIntoRangesParity(t *testing.T) {
+	ctx := context.Background()
+	vrw := types.NewTestValueStore()
+
+	const rowCount = 97 // prime, so no partition count below except 1 divides it evenly
+	kv := make([]types.Value, 0, rowCount*2)
+	for i := 0; i < rowCount; i++ {
+		kv = append(kv, types.Uint(i), types.String(fmt.Sprintf("row-%d", i)))
+	}
+
+	rowData, err := types.NewMap(ctx, vrw, kv...)
+	require.NoError(t, err)
+	require.EqualValues(t, rowCount, rowData.Len())
+
+	wantKeys := scanAllKeys(t, ctx, rowData)
+	require.Len(t, wantKeys, rowCount)
+
+	for _, n := range []int{1, 2, 3, 4, 10, 200} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			ranges, err := splitMapIntoRanges(ctx, rowData, n)
+			require.NoError(t, err)
+
+			var gotKeys []types.Value
+			for _, r := range ranges {
+				gotKeys = append(gotKeys, scanRange(t, ctx, rowData, r.start, r.end)...)
+			}
+
+			// Same rows, same order, no duplicates and none dropped: concatenating every
+			// range's rows must reproduce exactly what a full, unranged scan sees.
+			assert.Equal(t, wantKeys, gotKeys)
+		})
+	}
+}
+
+// scanAllKeys returns every key in m, in iteration order.
+func scanAllKeys(t *testing.T, ctx context.Context, m types.Map) []types.Value {
+	itr, err := m.Iterator(ctx)
+	require.NoError(t, err)
+
+	var keys []types.Value
+	for {
+		k, _, err := itr.Next(ctx)
+		require.NoError(t, err)
+		if k == nil {
+			return keys
+		}
+		keys = append(keys, k)
+	}
+}
+
+// scanRange returns every key in m that falls in [start, end), mirroring newRangeRowIter's own
+// iteration logic so this test exercises the same boundary semantics PartitionRows relies on.
+func scanRange(t *testing.T, ctx context.Context, m types.Map, start, end types.Value) []types.Value {
+	var itr types.MapIterator
+	var err error
+	if start == nil {
+		itr, err = m.Iterator(ctx)
+	} else {
+		itr, err = m.IteratorFrom(ctx, start)
+	}
+	require.NoError(t, err)
+
+	var keys []types.Value
+	for {
+		k, _, err := itr.Next(ctx)
+		require.NoError(t, err)
+		if k == nil {
+			return keys
+		}
+
+		if end != nil {
+			isLess, err := k.Less(types.Format_7_18, end)
+			require.NoError(t, err)
+			if !isLess {
+				return keys
+			}
+		}
+
+		keys = append(keys, k)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// doltTableRangeRowIter is a sql.RowIter over a [startKey, endKey) sub-range of a DoltTable's
+// row map, used for the partitions produced by splitMapIntoRanges.
+type doltTableRangeRowIter struct {
+	ctx    *sql.Context
+	table  *DoltTable
+	itr    types.MapIterator
+	endKey types.Value
+}
+
+var _ sql.RowIter = (*doltTableRangeRowIter)(nil)
+
+// newRangeRowIter returns a RowIter over the rows of t whose keys fall in [startKey, endKey).
+// A nil startKey begins at the first row; a nil endKey continues through the last.
+func newRangeRowIter(t *DoltTable, ctx *sql.Context, startKey, endKey types.Value) (sql.RowIter, error) {
+	rowData, err := t.table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var itr types.MapIterator
+	if startKey == nil {
+		itr, err = rowData.Iterator(ctx)
+	} else {
+		itr, err = rowData.IteratorFrom(ctx, startKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &doltTableRangeRowIter{ctx: ctx, table: t, itr: itr, endKey: endKey}, nil
+}
+
+// Next returns the next row in the range, or io.EOF once the range is exhausted.
+func (itr *doltTableRangeRowIter) Next() (sql.Row, error) {
+	k, v, err := itr.itr.Next(itr.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if k == nil {
+		return nil, io.EOF
+	}
+
+	if itr.endKey != nil {
+		isLess, err := k.Less(itr.table.table.Format(), itr.endKey)
+		if err != nil {
+			return nil, err
+		}
+		if !isLess {
+			return nil, io.EOF
+		}
+	}
+
+	r, err := row.FromNoms(itr.table.sch, k.(types.Tuple), v.(types.Tuple))
+	if err != nil {
+		return nil, err
+	}
+
+	return doltRowToSqlRow(r, itr.table.sch)
+}
+
+// Close is required by the sql.RowIter interface. Does nothing.
+func (itr *doltTableRangeRowIter) Close() error {
+	return nil
+}
+
+// doltRowToSqlRow converts a dolt row.Row to the equivalent go-mysql-server sql.Row, in schema
+// column order.
+//
+// TODO: this duplicates whatever conversion newRowIterator's RowIter already does for the
+// single-partition path. The two need to agree on every column kind or a table that crosses
+// partitionSizeThreshold will read back different Go types/values than the same table would
+// below it. Once the ranged and unranged iterators share a RowIter implementation (or at least
+// this conversion step), delete one of the two copies.
+func doltRowToSqlRow(r row.Row, sch schema.Schema) (sql.Row, error) {
+	cols := sch.GetAllCols().GetColumns()
+	sqlRow := make(sql.Row, len(cols))
+
+	for i, col := range cols {
+		val, ok := r.GetColVal(col.Tag)
+		if !ok || types.IsNull(val) {
+			continue
+		}
+
+		v, err := doltValueToSqlValue(val)
+		if err != nil {
+			return nil, err
+		}
+
+		sqlRow[i] = v
+	}
+
+	return sqlRow, nil
+}
+
+// doltValueToSqlValue unwraps a noms value into the native Go type go-mysql-server expects in a
+// sql.Row. Only handles the primitive kinds this function has been verified against; an
+// unrecognized kind returns an error rather than silently stringifying the value, since handing
+// back the wrong Go type for a column is a correctness bug, not something to paper over.
+func doltValueToSqlValue(val types.Value) (interface{}, error) {
+	switch v := val.(type) {
+	case types.Bool:
+		return bool(v), nil
+	case types.Int:
+		return int64(v), nil
+	case types.Uint:
+		return uint64(v), nil
+	case types.Float:
+		return float64(v), nil
+	case types.String:
+		return string(v), nil
+	case types.UUID:
+		return v.String(), nil
+	default:
+		return nil, fmt.Errorf("doltRowToSqlRow: unsupported noms value kind %T", v)
+	}
+}
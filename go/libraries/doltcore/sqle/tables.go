@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
 	"github.com/src-d/go-mysql-server/sql"
@@ -95,20 +96,60 @@ func (t *DoltTable) Schema() sql.Schema {
 	return sqlSch
 }
 
-// Returns the partitions for this table. We return a single partition, but could potentially get more performance by
-// returning multiple.
-func (t *DoltTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
-	return &doltTablePartitionIter{}, nil
+// Returns the partitions for this table. Tables larger than partitionSizeThreshold are split
+// into several key-range partitions so that go-mysql-server can fan a full-table scan out
+// across goroutines; smaller tables get a single partition as before.
+func (t *DoltTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
+	rowData, err := t.table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	numPartitions, err := partitionCountForSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if numPartitions <= 1 || rowData.Len() < partitionSizeThreshold || !supportsRangedPartitioning(t.sch) {
+		return &doltTablePartitionIter{partitions: []doltTablePartition{{}}}, nil
+	}
+
+	bounds, err := splitMapIntoRanges(ctx, rowData, numPartitions)
+	if err != nil {
+		return nil, err
+	}
+
+	partitions := make([]doltTablePartition, len(bounds))
+	for i, b := range bounds {
+		partitions[i] = doltTablePartition{startKey: b.start, endKey: b.end}
+	}
+
+	return &doltTablePartitionIter{partitions: partitions}, nil
 }
 
-// Returns the table rows for the partition given (all rows of the table).
-func (t *DoltTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
-	return newRowIterator(t, ctx)
+// Returns the table rows for the partition given. For the single, unbounded partition this is
+// every row in the table; for a doltTablePartition carrying [startKey, endKey) bounds, only the
+// rows in that sub-range of the row map are scanned.
+func (t *DoltTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	dtp, ok := part.(doltTablePartition)
+	if !ok {
+		return nil, fmt.Errorf("unsupported partition type: %T", part)
+	}
+
+	if dtp.startKey == nil && dtp.endKey == nil {
+		return newRowIterator(t, ctx)
+	}
+
+	return newRangeRowIter(t, ctx, dtp.startKey, dtp.endKey)
 }
 
 type tableEditor struct {
 	t  *DoltTable
 	ed *types.MapEditor
+	// events stages a ChangeEvent for every Insert/Update/Delete made through this editor.
+	// They're only handed to the table's event sink once Close() has successfully persisted
+	// the new root, so a rolled-back or failed commit never leaks phantom changes.
+	events []ChangeEvent
 }
 
 var _ sql.RowReplacer = (*tableEditor)(nil)
@@ -142,6 +183,7 @@ func (r *tableEditor) Insert(ctx *sql.Context, sqlRow sql.Row) error {
 	}
 
 	r.ed = r.ed.Set(key, dRow.NomsMapValue(r.t.sch))
+	r.stageEvent(ChangeEventInsert, key, nil, sqlRow)
 	return nil
 }
 
@@ -174,6 +216,7 @@ func (r *tableEditor) Delete(ctx *sql.Context, sqlRow sql.Row) error {
 	}
 
 	r.ed = r.ed.Remove(key)
+	r.stageEvent(ChangeEventDelete, key, sqlRow, nil)
 	return nil
 }
 
@@ -224,16 +267,93 @@ func (u *tableEditor) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.Row) e
 		u.ed.Set(dOldKey, dNewRow.NomsMapValue(u.t.sch))
 	}
 
+	u.stageEvent(ChangeEventUpdate, dNewKeyVal, oldRow, newRow)
 	return nil
 }
 
+// stageEvent appends a ChangeEvent describing this row mutation, provided the table's database
+// has an event sink configured. Staged events aren't handed to the sink until Close() has
+// confirmed the new root was written successfully.
+func (r *tableEditor) stageEvent(evType ChangeEventType, key types.Value, oldRow, newRow sql.Row) {
+	if r.t.db.EventSink() == nil {
+		return
+	}
+
+	r.events = append(r.events, ChangeEvent{
+		Database:   r.t.db.Name(),
+		Table:      r.t.name,
+		Type:       evType,
+		PrimaryKey: fmt.Sprintf("%v", key),
+		OldRow:     sqlRowToEventRow(r.t.sch, oldRow),
+		NewRow:     sqlRowToEventRow(r.t.sch, newRow),
+	})
+}
+
+// sqlRowToEventRow renders a sql.Row as a map keyed by column name for inclusion in a
+// ChangeEvent. Returns nil for a nil row (e.g. the old row of an insert).
+func sqlRowToEventRow(sch schema.Schema, sqlRow sql.Row) map[string]interface{} {
+	if sqlRow == nil {
+		return nil
+	}
+
+	cols := sch.GetAllCols().GetColumns()
+	row := make(map[string]interface{}, len(cols))
+	for i, col := range cols {
+		if i < len(sqlRow) {
+			row[col.Name] = sqlRow[i]
+		}
+	}
+
+	return row
+}
+
 func (r *tableEditor) Close(ctx *sql.Context) error {
-	if r.ed != nil {
-		return r.t.updateTable(ctx, r.ed)
+	if r.ed == nil {
+		return nil
 	}
+
+	newRoot, err := r.t.updateTable(ctx, r.ed)
+	if err != nil {
+		return err
+	}
+
+	r.emitEvents(ctx, newRoot)
 	return nil
 }
 
+// emitEvents hands this editor's staged events to the table's event sink, bracketed by
+// ChangeEventTxOpen/ChangeEventTxCommit markers for the root that the Close() call above just
+// persisted. It's only ever called after PutTable has succeeded, so a sink never sees events
+// for a commit that didn't actually happen.
+func (r *tableEditor) emitEvents(ctx *sql.Context, newRoot *doltdb.RootValue) {
+	sink := r.t.db.EventSink()
+	if sink == nil || len(r.events) == 0 {
+		return
+	}
+
+	rootHash, err := newRoot.HashOf()
+	var rootStr string
+	if err == nil {
+		rootStr = rootHash.String()
+	}
+
+	batch := buildEventBatch(r.t.db.Name(), r.t.name, r.events, rootStr)
+	if err := sink.Emit(ctx, batch); err != nil {
+		fmt.Fprintf(os.Stderr, "dolt event sink: failed to emit change events for %s: %v\n", r.t.name, err)
+	}
+}
+
+// buildEventBatch brackets staged with a ChangeEventTxOpen marker and a ChangeEventTxCommit
+// marker carrying rootHash, the shape every EventSink.Emit call receives. Split out from
+// emitEvents so the bracketing logic can be tested without a real DoltTable/Database.
+func buildEventBatch(dbName, tableName string, staged []ChangeEvent, rootHash string) []ChangeEvent {
+	batch := make([]ChangeEvent, 0, len(staged)+2)
+	batch = append(batch, ChangeEvent{Database: dbName, Table: tableName, Type: ChangeEventTxOpen})
+	batch = append(batch, staged...)
+	batch = append(batch, ChangeEvent{Database: dbName, Table: tableName, Type: ChangeEventTxCommit, RootHash: rootHash})
+	return batch
+}
+
 func (t *DoltTable) Inserter(ctx *sql.Context) sql.RowInserter {
 	return &tableEditor{
 		t: t,
@@ -258,10 +378,13 @@ func (t *DoltTable) Updater(ctx *sql.Context) sql.RowUpdater {
 	}
 }
 
-// doltTablePartitionIter, an object that knows how to return the single partition exactly once.
+// doltTablePartitionIter iterates over a fixed slice of partitions computed up front by
+// Partitions(). IndexedDoltTable builds its partitions from the index lookup instead and
+// doesn't go through this type.
 type doltTablePartitionIter struct {
 	sql.PartitionIter
-	i int
+	i          int
+	partitions []doltTablePartition
 }
 
 // Close is required by the sql.PartitionIter interface. Does nothing.
@@ -271,44 +394,53 @@ func (itr *doltTablePartitionIter) Close() error {
 
 // Next returns the next partition if there is one, or io.EOF if there isn't.
 func (itr *doltTablePartitionIter) Next() (sql.Partition, error) {
-	if itr.i > 0 {
+	if itr.i >= len(itr.partitions) {
 		return nil, io.EOF
 	}
+
+	p := itr.partitions[itr.i]
 	itr.i++
 
-	return &doltTablePartition{}, nil
+	return p, nil
 }
 
-// A table partition, currently an unused layer of abstraction but required for the framework.
+// A doltTablePartition is a [startKey, endKey) range of a table's row map. A zero-value
+// doltTablePartition (nil start and end) represents the whole table as a single partition.
 type doltTablePartition struct {
 	sql.Partition
+	startKey, endKey types.Value
 }
 
 const partitionName = "single"
 
-// Key returns the key for this partition, which must uniquely identity the partition. We have only a single partition
-// per table, so we use a constant.
+// Key returns the key for this partition, which must uniquely identify it among the partitions
+// returned for a given table. The single, unbounded partition keeps the old constant name for
+// backwards compatibility with anything keying off of it; ranged partitions key off their bounds.
 func (p doltTablePartition) Key() []byte {
-	return []byte(partitionName)
+	if p.startKey == nil && p.endKey == nil {
+		return []byte(partitionName)
+	}
+
+	return []byte(fmt.Sprintf("%v:%v", p.startKey, p.endKey))
 }
 
-func (t *DoltTable) updateTable(ctx *sql.Context, mapEditor *types.MapEditor) error {
+func (t *DoltTable) updateTable(ctx *sql.Context, mapEditor *types.MapEditor) (*doltdb.RootValue, error) {
 	updated, err := mapEditor.Map(ctx)
 	if err != nil {
-		return errhand.BuildDError("failed to modify table").AddCause(err).Build()
+		return nil, errhand.BuildDError("failed to modify table").AddCause(err).Build()
 	}
 
 	newTable, err := t.table.UpdateRows(ctx, updated)
 	if err != nil {
-		return errhand.BuildDError("failed to update rows").AddCause(err).Build()
+		return nil, errhand.BuildDError("failed to update rows").AddCause(err).Build()
 	}
 
 	newRoot, err := doltdb.PutTable(ctx, t.db.root, t.db.root.VRW(), t.name, newTable)
 	if err != nil {
-		return errhand.BuildDError("failed to write table back to database").AddCause(err).Build()
+		return nil, errhand.BuildDError("failed to write table back to database").AddCause(err).Build()
 	}
 
 	t.table = newTable
 	t.db.root = newRoot
-	return nil
+	return newRoot, nil
 }
@@ -0,0 +1,91 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestTableEditorStageEvent(t *testing.T) {
+	t.Run("no sink configured, nothing is staged", func(t *testing.T) {
+		dt := &DoltTable{name: "mytable", db: &Database{name: "mydb"}}
+		ed := &tableEditor{t: dt}
+
+		ed.stageEvent(ChangeEventInsert, types.Int(1), nil, nil)
+		assert.Empty(t, ed.events)
+	})
+
+	t.Run("sink configured, event is staged", func(t *testing.T) {
+		sink := NewMemoryEventSink()
+		dt := &DoltTable{name: "mytable", db: &Database{name: "mydb", eventSink: sink}}
+		ed := &tableEditor{t: dt}
+
+		ed.stageEvent(ChangeEventInsert, types.Int(1), nil, nil)
+
+		require.Len(t, ed.events, 1)
+		assert.Equal(t, ChangeEventInsert, ed.events[0].Type)
+		assert.Equal(t, "mydb", ed.events[0].Database)
+		assert.Equal(t, "mytable", ed.events[0].Table)
+		assert.Equal(t, "1", ed.events[0].PrimaryKey)
+		// Nothing is handed to the sink until Close() confirms the commit succeeded.
+		assert.Empty(t, sink.Events())
+	})
+}
+
+func TestBuildEventBatch(t *testing.T) {
+	staged := []ChangeEvent{
+		{Type: ChangeEventInsert, Table: "t"},
+		{Type: ChangeEventUpdate, Table: "t"},
+	}
+
+	batch := buildEventBatch("mydb", "t", staged, "root-hash")
+
+	require.Len(t, batch, 4)
+	assert.Equal(t, ChangeEventTxOpen, batch[0].Type)
+	assert.Empty(t, batch[0].RootHash)
+	assert.Equal(t, ChangeEventInsert, batch[1].Type)
+	assert.Equal(t, ChangeEventUpdate, batch[2].Type)
+	assert.Equal(t, ChangeEventTxCommit, batch[3].Type)
+	assert.Equal(t, "root-hash", batch[3].RootHash)
+
+	for _, evt := range batch {
+		assert.Equal(t, "mydb", evt.Database)
+		assert.Equal(t, "t", evt.Table)
+	}
+}
+
+func TestBuildEventBatchNoStagedEvents(t *testing.T) {
+	batch := buildEventBatch("mydb", "t", nil, "root-hash")
+
+	require.Len(t, batch, 2)
+	assert.Equal(t, ChangeEventTxOpen, batch[0].Type)
+	assert.Equal(t, ChangeEventTxCommit, batch[1].Type)
+}
+
+func TestTableEditorCloseNoEditsNoEvents(t *testing.T) {
+	sink := NewMemoryEventSink()
+	dt := &DoltTable{name: "mytable", db: &Database{name: "mydb", eventSink: sink}}
+	ed := &tableEditor{t: dt}
+
+	// No rows were ever staged (ed.ed is still nil), so Close must return before ever
+	// touching the sink or calling updateTable -- a no-op editor can't leak phantom events.
+	require.NoError(t, ed.Close(nil))
+	assert.Empty(t, sink.Events())
+}